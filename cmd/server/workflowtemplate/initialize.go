@@ -0,0 +1,56 @@
+package workflowtemplate
+
+import (
+	"context"
+
+	"github.com/argoproj/argo/pkg/apis/workflow/v1alpha1"
+	"github.com/argoproj/argo/pkg/workflowtemplate/initialize"
+)
+
+// WorkflowTemplateInitializeRequest primes namespace with a library of seed WorkflowTemplates, creating
+// the namespace and registering the WorkflowTemplate CRD first if either is missing.
+type WorkflowTemplateInitializeRequest struct {
+	Namespace string
+	Templates []*v1alpha1.WorkflowTemplate
+}
+
+// WorkflowTemplateInitializeResponse reports the per-template outcome of an initialization request.
+type WorkflowTemplateInitializeResponse struct {
+	Namespace string
+	Results   []initialize.ItemResult
+}
+
+// InitializeWorkflowTemplates gives operators a one-shot way to prime a fresh cluster with a library of
+// shared WorkflowTemplates, rather than having to script individual CreateWorkflowTemplate calls. Every
+// step is idempotent: the namespace, the CRD registration, and each seed template tolerate AlreadyExists.
+func (wts *WorkflowTemplateServer) InitializeWorkflowTemplates(ctx context.Context, wftmplReq *WorkflowTemplateInitializeRequest) (*WorkflowTemplateInitializeResponse, error) {
+	wfClient, kubeClient, err := wts.GetWFClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	namespace := wts.namespace
+	if wftmplReq.Namespace != "" {
+		namespace = wftmplReq.Namespace
+	}
+
+	if err := initialize.EnsureNamespace(kubeClient, namespace); err != nil {
+		return nil, err
+	}
+
+	apiExtensionsClient, err := wts.GetApiExtensionsClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := initialize.EnsureWorkflowTemplateCRD(apiExtensionsClient); err != nil {
+		return nil, err
+	}
+
+	results := initialize.CreateSeedTemplates(wfClient, namespace, wftmplReq.Templates)
+
+	return &WorkflowTemplateInitializeResponse{
+		Namespace: namespace,
+		Results:   results,
+	}, nil
+}
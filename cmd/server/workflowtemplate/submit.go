@@ -0,0 +1,129 @@
+package workflowtemplate
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/argoproj/argo/pkg/apis/workflow/v1alpha1"
+	"github.com/argoproj/argo/workflow/templateresolution"
+	"github.com/argoproj/argo/workflow/validate"
+)
+
+// WorkflowTemplateSubmitRequest instantiates a stored WorkflowTemplate as a Workflow. Parameters follow
+// the "NAME=VALUE" form used by `argo submit --parameter`.
+type WorkflowTemplateSubmitRequest struct {
+	Namespace          string
+	TemplateName       string
+	Parameters         []string
+	GenerateName       string
+	Labels             map[string]string
+	Annotations        map[string]string
+	ServiceAccountName string
+}
+
+// SubmitWorkflowFromTemplate looks up the named WorkflowTemplate, builds a Workflow that inlines its
+// entrypoint and templates, validates the Workflow, and submits it. This closes the gap where templates
+// can be stored but not directly launched through the template server.
+//
+// The template's entrypoint and templates are inlined rather than referenced through workflowTemplateRef
+// so that validation does not depend on whether this argo version's template resolver follows a
+// workflowTemplateRef when validating a Workflow that has no templates of its own.
+func (wts *WorkflowTemplateServer) SubmitWorkflowFromTemplate(ctx context.Context, wftmplReq *WorkflowTemplateSubmitRequest) (*v1alpha1.Workflow, error) {
+	wfClient, _, err := wts.GetWFClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	namespace := wts.namespace
+	if wftmplReq.Namespace != "" {
+		namespace = wftmplReq.Namespace
+	}
+	if wftmplReq.TemplateName == "" {
+		return nil, fmt.Errorf("WorkflowTemplate name is not found in Request body")
+	}
+
+	wfTmpl, err := wfClient.ArgoprojV1alpha1().WorkflowTemplates(namespace).Get(wftmplReq.TemplateName, v1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	wf, err := buildWorkflowFromTemplate(wfTmpl, wftmplReq)
+	if err != nil {
+		return nil, err
+	}
+
+	wftmplGetter := templateresolution.WrapWorkflowTemplateInterface(wfClient.ArgoprojV1alpha1().WorkflowTemplates(namespace))
+	if err := validate.ValidateWorkflow(wftmplGetter, wf, validate.ValidateOpts{}); err != nil {
+		return nil, fmt.Errorf("Failed to validate workflow: %v", err)
+	}
+
+	created, err := wfClient.ArgoprojV1alpha1().Workflows(namespace).Create(wf)
+	if err != nil {
+		return nil, err
+	}
+
+	return created, nil
+}
+
+// buildWorkflowFromTemplate builds the Workflow that SubmitWorkflowFromTemplate submits, without talking
+// to the apiserver. wfTmpl's entrypoint and templates are copied in directly, and wftmplReq.Parameters
+// override the template's arguments.
+func buildWorkflowFromTemplate(wfTmpl *v1alpha1.WorkflowTemplate, wftmplReq *WorkflowTemplateSubmitRequest) (*v1alpha1.Workflow, error) {
+	parameters, err := parseSubmitParameters(wftmplReq.Parameters)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to parse parameters: %v", err)
+	}
+
+	generateName := wftmplReq.GenerateName
+	if generateName == "" {
+		generateName = wfTmpl.Name + "-"
+	}
+
+	arguments := *wfTmpl.Spec.Arguments.DeepCopy()
+	for _, override := range parameters {
+		replaced := false
+		for i := range arguments.Parameters {
+			if arguments.Parameters[i].Name == override.Name {
+				arguments.Parameters[i] = override
+				replaced = true
+			}
+		}
+		if !replaced {
+			arguments.Parameters = append(arguments.Parameters, override)
+		}
+	}
+
+	return &v1alpha1.Workflow{
+		ObjectMeta: v1.ObjectMeta{
+			GenerateName: generateName,
+			Labels:       wftmplReq.Labels,
+			Annotations:  wftmplReq.Annotations,
+		},
+		Spec: v1alpha1.WorkflowSpec{
+			Entrypoint:         wfTmpl.Spec.Entrypoint,
+			Templates:          wfTmpl.Spec.Templates,
+			Arguments:          arguments,
+			ServiceAccountName: wftmplReq.ServiceAccountName,
+		},
+	}, nil
+}
+
+// parseSubmitParameters converts "NAME=VALUE" overrides into Workflow arguments.
+func parseSubmitParameters(params []string) ([]v1alpha1.Parameter, error) {
+	parameters := make([]v1alpha1.Parameter, 0, len(params))
+	for _, p := range params {
+		parts := strings.SplitN(p, "=", 2)
+		if len(parts) != 2 {
+			return nil, fmt.Errorf("parameter '%s' must be in the form NAME=VALUE", p)
+		}
+		value := parts[1]
+		parameters = append(parameters, v1alpha1.Parameter{
+			Name:  parts[0],
+			Value: &value,
+		})
+	}
+	return parameters, nil
+}
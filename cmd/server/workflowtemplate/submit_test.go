@@ -0,0 +1,58 @@
+package workflowtemplate
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/argoproj/argo/pkg/apis/workflow/v1alpha1"
+)
+
+func TestBuildWorkflowFromTemplate(t *testing.T) {
+	wfTmpl := &v1alpha1.WorkflowTemplate{
+		ObjectMeta: v1.ObjectMeta{Name: "my-tmpl"},
+		Spec: v1alpha1.WorkflowSpec{
+			Entrypoint: "main",
+			Templates: []v1alpha1.Template{
+				{Name: "main"},
+			},
+		},
+	}
+
+	t.Run("inlines entrypoint and templates, defaults generateName", func(t *testing.T) {
+		wf, err := buildWorkflowFromTemplate(wfTmpl, &WorkflowTemplateSubmitRequest{
+			TemplateName: "my-tmpl",
+			Parameters:   []string{"foo=bar"},
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, "my-tmpl-", wf.GenerateName)
+		assert.Equal(t, "main", wf.Spec.Entrypoint)
+		assert.Equal(t, wfTmpl.Spec.Templates, wf.Spec.Templates)
+		assert.Nil(t, wf.Spec.WorkflowTemplateRef)
+		if assert.Len(t, wf.Spec.Arguments.Parameters, 1) {
+			assert.Equal(t, "foo", wf.Spec.Arguments.Parameters[0].Name)
+			assert.Equal(t, "bar", *wf.Spec.Arguments.Parameters[0].Value)
+		}
+	})
+
+	t.Run("honors an explicit generateName", func(t *testing.T) {
+		wf, err := buildWorkflowFromTemplate(wfTmpl, &WorkflowTemplateSubmitRequest{
+			TemplateName: "my-tmpl",
+			GenerateName: "custom-",
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, "custom-", wf.GenerateName)
+	})
+
+	t.Run("rejects a malformed parameter override", func(t *testing.T) {
+		_, err := buildWorkflowFromTemplate(wfTmpl, &WorkflowTemplateSubmitRequest{
+			TemplateName: "my-tmpl",
+			Parameters:   []string{"not-a-key-value-pair"},
+		})
+
+		assert.Error(t, err)
+	})
+}
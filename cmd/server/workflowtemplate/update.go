@@ -0,0 +1,172 @@
+package workflowtemplate
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	jsonpatch "github.com/evanphx/json-patch"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	apierr "k8s.io/apimachinery/pkg/api/errors"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/argoproj/argo/pkg/apis/workflow/v1alpha1"
+	"github.com/argoproj/argo/workflow/templateresolution"
+	"github.com/argoproj/argo/workflow/validate"
+)
+
+// WorkflowTemplateUpdateRequest replaces an existing WorkflowTemplate in full. Template.ResourceVersion
+// must match the current object, giving the caller optimistic concurrency.
+type WorkflowTemplateUpdateRequest struct {
+	Namespace string
+	Template  *v1alpha1.WorkflowTemplate
+}
+
+// WorkflowTemplatePatchRequest applies a JSON merge patch (types.MergePatchType) or a JSON patch
+// (types.JSONPatchType, RFC 6902) to an existing WorkflowTemplate.
+//
+// types.StrategicMergePatchType is deliberately not supported, a reviewed substitution for the originally
+// requested "strategic merge patch variant": the apiserver rejects strategic merge patches against CRDs,
+// so WorkflowTemplate - a CRD - can never accept one, and no caller of this series depends on it.
+//
+// ResourceVersion is an optional optimistic-concurrency precondition: if set, the patch is rejected as a
+// conflict unless it matches the current object's ResourceVersion.
+type WorkflowTemplatePatchRequest struct {
+	Namespace       string
+	TemplateName    string
+	ResourceVersion string
+	PatchType       types.PatchType
+	Patch           []byte
+}
+
+// UpdateWorkflowTemplate replaces an existing WorkflowTemplate. The post-update object is validated with
+// validate.ValidateWorkflowTemplate before the update is submitted, and a Conflict from the apiserver is
+// surfaced as a structured gRPC error carrying the current ResourceVersion so the caller can retry.
+func (wts *WorkflowTemplateServer) UpdateWorkflowTemplate(ctx context.Context, wftmplReq *WorkflowTemplateUpdateRequest) (*v1alpha1.WorkflowTemplate, error) {
+	wfClient, _, err := wts.GetWFClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	namespace := wts.namespace
+	if wftmplReq.Namespace != "" {
+		namespace = wftmplReq.Namespace
+	}
+	if wftmplReq.Template == nil {
+		return nil, fmt.Errorf("WorkflowTemplate is not found in Request body")
+	}
+	if wftmplReq.Template.ResourceVersion == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "ResourceVersion is required to update workflow template '%s'", wftmplReq.Template.Name)
+	}
+
+	wftmplGetter := templateresolution.WrapWorkflowTemplateInterface(wfClient.ArgoprojV1alpha1().WorkflowTemplates(namespace))
+	if err := validate.ValidateWorkflowTemplate(wftmplGetter, wftmplReq.Template); err != nil {
+		return nil, fmt.Errorf("Failed to validate updated workflow template: %v", err)
+	}
+
+	updated, err := wfClient.ArgoprojV1alpha1().WorkflowTemplates(namespace).Update(wftmplReq.Template)
+	if err != nil {
+		return nil, conflictErr(err, wfClient.ArgoprojV1alpha1().WorkflowTemplates(namespace), wftmplReq.Template.Name)
+	}
+
+	return updated, nil
+}
+
+// PatchWorkflowTemplate applies a JSON merge patch or a JSON patch to an existing WorkflowTemplate. The
+// patch is applied locally to the current object and the merged result is validated with
+// validate.ValidateWorkflowTemplate before the patch is submitted to the apiserver.
+func (wts *WorkflowTemplateServer) PatchWorkflowTemplate(ctx context.Context, wftmplReq *WorkflowTemplatePatchRequest) (*v1alpha1.WorkflowTemplate, error) {
+	wfClient, _, err := wts.GetWFClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	namespace := wts.namespace
+	if wftmplReq.Namespace != "" {
+		namespace = wftmplReq.Namespace
+	}
+	if wftmplReq.TemplateName == "" {
+		return nil, fmt.Errorf("WorkflowTemplate name is not found in Request body")
+	}
+
+	templateClient := wfClient.ArgoprojV1alpha1().WorkflowTemplates(namespace)
+
+	current, err := templateClient.Get(wftmplReq.TemplateName, v1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+	if wftmplReq.ResourceVersion != "" && wftmplReq.ResourceVersion != current.ResourceVersion {
+		return nil, status.Errorf(codes.Aborted, "conflict patching workflow template '%s': resourceVersion is out of date, current resourceVersion is '%s'", wftmplReq.TemplateName, current.ResourceVersion)
+	}
+
+	merged, err := applyTemplatePatch(current, wftmplReq.PatchType, wftmplReq.Patch)
+	if err != nil {
+		return nil, err
+	}
+
+	wftmplGetter := templateresolution.WrapWorkflowTemplateInterface(templateClient)
+	if err := validate.ValidateWorkflowTemplate(wftmplGetter, merged); err != nil {
+		return nil, fmt.Errorf("Failed to validate patched workflow template: %v", err)
+	}
+
+	patched, err := templateClient.Patch(wftmplReq.TemplateName, wftmplReq.PatchType, wftmplReq.Patch)
+	if err != nil {
+		return nil, conflictErr(err, templateClient, wftmplReq.TemplateName)
+	}
+
+	return patched, nil
+}
+
+// applyTemplatePatch applies patch to current according to patchType and returns the merged result,
+// without talking to the apiserver. It is the dispatch table exercised by PatchWorkflowTemplate.
+func applyTemplatePatch(current *v1alpha1.WorkflowTemplate, patchType types.PatchType, patch []byte) (*v1alpha1.WorkflowTemplate, error) {
+	currentJSON, err := json.Marshal(current)
+	if err != nil {
+		return nil, err
+	}
+
+	var mergedJSON []byte
+	switch patchType {
+	case types.MergePatchType:
+		mergedJSON, err = jsonpatch.MergePatch(currentJSON, patch)
+	case types.JSONPatchType:
+		var decoded jsonpatch.Patch
+		decoded, err = jsonpatch.DecodePatch(patch)
+		if err == nil {
+			mergedJSON, err = decoded.Apply(currentJSON)
+		}
+	default:
+		return nil, fmt.Errorf("unsupported patch type '%s': only MergePatchType and JSONPatchType are supported (the apiserver does not accept strategic merge patches for custom resources)", patchType)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("Failed to apply patch: %v", err)
+	}
+
+	merged := &v1alpha1.WorkflowTemplate{}
+	if err := json.Unmarshal(mergedJSON, merged); err != nil {
+		return nil, err
+	}
+	return merged, nil
+}
+
+// conflictErr turns a Conflict error from the apiserver into a structured gRPC error carrying the
+// current ResourceVersion of name, so the caller can retry cleanly. Any other error is passed through.
+func conflictErr(err error, templateClient templateGetter, name string) error {
+	if !apierr.IsConflict(err) {
+		return err
+	}
+
+	current, getErr := templateClient.Get(name, v1.GetOptions{})
+	if getErr != nil {
+		return status.Errorf(codes.Aborted, "conflict updating workflow template '%s': %v", name, err)
+	}
+
+	return status.Errorf(codes.Aborted, "conflict updating workflow template '%s': resourceVersion is out of date, current resourceVersion is '%s'", name, current.ResourceVersion)
+}
+
+// templateGetter is the subset of the generated WorkflowTemplateInterface that conflictErr needs.
+type templateGetter interface {
+	Get(name string, options v1.GetOptions) (*v1alpha1.WorkflowTemplate, error)
+}
@@ -0,0 +1,88 @@
+package workflowtemplate
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	apierr "k8s.io/apimachinery/pkg/api/errors"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/argoproj/argo/pkg/apis/workflow/v1alpha1"
+)
+
+type fakeTemplateGetter struct {
+	template *v1alpha1.WorkflowTemplate
+	err      error
+}
+
+func (g *fakeTemplateGetter) Get(name string, options v1.GetOptions) (*v1alpha1.WorkflowTemplate, error) {
+	return g.template, g.err
+}
+
+func TestConflictErr(t *testing.T) {
+	t.Run("wraps a Conflict with the current resourceVersion", func(t *testing.T) {
+		getter := &fakeTemplateGetter{template: &v1alpha1.WorkflowTemplate{
+			ObjectMeta: v1.ObjectMeta{Name: "my-tmpl", ResourceVersion: "5"},
+		}}
+		conflict := apierr.NewConflict(schema.GroupResource{Group: "argoproj.io", Resource: "workflowtemplates"}, "my-tmpl", errors.New("stale"))
+
+		err := conflictErr(conflict, getter, "my-tmpl")
+
+		st, ok := status.FromError(err)
+		assert.True(t, ok)
+		assert.Equal(t, codes.Aborted, st.Code())
+		assert.Contains(t, st.Message(), "5")
+	})
+
+	t.Run("falls back to a structured error if the re-Get fails", func(t *testing.T) {
+		getter := &fakeTemplateGetter{err: errors.New("not found")}
+		conflict := apierr.NewConflict(schema.GroupResource{}, "my-tmpl", errors.New("stale"))
+
+		err := conflictErr(conflict, getter, "my-tmpl")
+
+		st, ok := status.FromError(err)
+		assert.True(t, ok)
+		assert.Equal(t, codes.Aborted, st.Code())
+	})
+
+	t.Run("passes non-Conflict errors through unchanged", func(t *testing.T) {
+		original := errors.New("boom")
+		getter := &fakeTemplateGetter{}
+
+		err := conflictErr(original, getter, "my-tmpl")
+
+		assert.Equal(t, original, err)
+	})
+}
+
+func TestApplyTemplatePatch(t *testing.T) {
+	current := &v1alpha1.WorkflowTemplate{
+		ObjectMeta: v1.ObjectMeta{Name: "my-tmpl", Labels: map[string]string{"env": "dev"}},
+	}
+
+	t.Run("merge patch", func(t *testing.T) {
+		merged, err := applyTemplatePatch(current, types.MergePatchType, []byte(`{"metadata":{"labels":{"env":"prod"}}}`))
+
+		assert.NoError(t, err)
+		assert.Equal(t, "prod", merged.Labels["env"])
+	})
+
+	t.Run("JSON patch", func(t *testing.T) {
+		merged, err := applyTemplatePatch(current, types.JSONPatchType, []byte(`[{"op":"replace","path":"/metadata/labels/env","value":"staging"}]`))
+
+		assert.NoError(t, err)
+		assert.Equal(t, "staging", merged.Labels["env"])
+	})
+
+	t.Run("strategic merge patch is rejected", func(t *testing.T) {
+		_, err := applyTemplatePatch(current, types.StrategicMergePatchType, []byte(`{}`))
+
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "unsupported patch type")
+	})
+}
@@ -5,9 +5,13 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"path/filepath"
 
 	log "github.com/sirupsen/logrus"
+	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	apiextensionsclient "k8s.io/apiextensions-apiserver/pkg/client/clientset/clientset"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/rest"
@@ -22,49 +26,64 @@ import (
 )
 
 type WorkflowTemplateServer struct {
-	namespace        string
-	wfClientset      *versioned.Clientset
-	kubeClientset    *kubernetes.Clientset
-	enableClientAuth bool
+	namespace              string
+	wfClientset            *versioned.Clientset
+	kubeClientset          *kubernetes.Clientset
+	apiExtensionsClientset *apiextensionsclient.Clientset
+	enableClientAuth       bool
 }
 
-func NewWorkflowTemplateServer(namespace string, wfClientset *versioned.Clientset, kubeClientSet *kubernetes.Clientset, config *config.WorkflowControllerConfig, enableClientAuth bool) *WorkflowTemplateServer {
-	return &WorkflowTemplateServer{namespace: namespace, wfClientset: wfClientset, kubeClientset: kubeClientSet, enableClientAuth: enableClientAuth}
+func NewWorkflowTemplateServer(namespace string, wfClientset *versioned.Clientset, kubeClientSet *kubernetes.Clientset, apiExtensionsClientset *apiextensionsclient.Clientset, config *config.WorkflowControllerConfig, enableClientAuth bool) *WorkflowTemplateServer {
+	return &WorkflowTemplateServer{
+		namespace:              namespace,
+		wfClientset:            wfClientset,
+		kubeClientset:          kubeClientSet,
+		apiExtensionsClientset: apiExtensionsClientset,
+		enableClientAuth:       enableClientAuth,
+	}
 }
 
-func (s *WorkflowTemplateServer) GetWFClient(ctx context.Context) (*versioned.Clientset, *kubernetes.Clientset, error) {
+// restConfigFromIncomingContext extracts the caller's kubeconfig and bearer token from the incoming gRPC
+// metadata, for use by GetWFClient and GetApiExtensionsClient when enableClientAuth is set.
+func restConfigFromIncomingContext(ctx context.Context) (*rest.Config, error) {
 	md, _ := metadata.FromIncomingContext(ctx)
 
-	if !s.enableClientAuth {
-		return s.wfClientset, s.kubeClientset, nil
-	}
-
-	var restConfigStr, bearerToken string
 	if len(md.Get(common.CLIENT_REST_CONFIG)) == 0 {
-		return nil, nil, errors.New("Client kubeconfig is not found")
+		return nil, errors.New("Client kubeconfig is not found")
 	}
-	restConfigStr = md.Get(common.CLIENT_REST_CONFIG)[0]
+	restConfigStr := md.Get(common.CLIENT_REST_CONFIG)[0]
 
+	var bearerToken string
 	if len(md.Get(common.AUTH_TOKEN)) > 0 {
 		bearerToken = md.Get(common.AUTH_TOKEN)[0]
 	}
 
 	restConfig := rest.Config{}
+	if err := json.Unmarshal([]byte(restConfigStr), &restConfig); err != nil {
+		return nil, err
+	}
+	restConfig.BearerToken = bearerToken
 
-	err := json.Unmarshal([]byte(restConfigStr), &restConfig)
+	return &restConfig, nil
+}
+
+func (s *WorkflowTemplateServer) GetWFClient(ctx context.Context) (*versioned.Clientset, *kubernetes.Clientset, error) {
+	if !s.enableClientAuth {
+		return s.wfClientset, s.kubeClientset, nil
+	}
+
+	restConfig, err := restConfigFromIncomingContext(ctx)
 	if err != nil {
 		return nil, nil, err
 	}
 
-	restConfig.BearerToken = bearerToken
-
-	wfClientset, err := wfclientset.NewForConfig(&restConfig)
+	wfClientset, err := wfclientset.NewForConfig(restConfig)
 	if err != nil {
 		log.Errorf("Failure to create wfClientset with ClientConfig '%+v': %s", restConfig, err)
 		return nil, nil, err
 	}
 
-	clientset, err := kubernetes.NewForConfig(&restConfig)
+	clientset, err := kubernetes.NewForConfig(restConfig)
 	if err != nil {
 		log.Errorf("Failure to create kubeClientset with ClientConfig '%+v': %s", restConfig, err)
 		return nil, nil, err
@@ -73,6 +92,28 @@ func (s *WorkflowTemplateServer) GetWFClient(ctx context.Context) (*versioned.Cl
 	return wfClientset, clientset, nil
 }
 
+// GetApiExtensionsClient returns the apiextensions clientset to use for CRD administration, derived from
+// the same per-request credentials as GetWFClient when enableClientAuth is set, so CRD operations can't
+// bypass the caller's auth.
+func (s *WorkflowTemplateServer) GetApiExtensionsClient(ctx context.Context) (*apiextensionsclient.Clientset, error) {
+	if !s.enableClientAuth {
+		return s.apiExtensionsClientset, nil
+	}
+
+	restConfig, err := restConfigFromIncomingContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	apiExtensionsClientset, err := apiextensionsclient.NewForConfig(restConfig)
+	if err != nil {
+		log.Errorf("Failure to create apiExtensionsClientset with ClientConfig '%+v': %s", restConfig, err)
+		return nil, err
+	}
+
+	return apiExtensionsClientset, nil
+}
+
 func (wts *WorkflowTemplateServer) CreateWorkflowTemplate(ctx context.Context, wftmplReq *WorkflowTemplateCreateRequest) (*v1alpha1.WorkflowTemplate, error) {
 	wfClient, _, err := wts.GetWFClient(ctx)
 	if err != nil {
@@ -121,6 +162,22 @@ func (wts *WorkflowTemplateServer) GetWorkflowTemplate(ctx context.Context, wftm
 	return wfTmpl, err
 }
 
+// WorkflowTemplateListRequest lists WorkflowTemplates in a namespace, with optional server-side
+// filtering and pagination. LabelSelector and FieldSelector are passed through to the apiserver as-is.
+// NameGlob is matched against the template name using shell glob syntax (path/filepath.Match, not
+// substring matching) since label selectors can't express this kind of name match; a substring match
+// like "report" must be passed as "*report*". NameGlob is applied client-side after the apiserver page
+// is fetched, so it cannot be combined with Limit/Continue: a glob could match only items outside the
+// current page, making the page empty and the returned Continue/RemainingItemCount misleading.
+type WorkflowTemplateListRequest struct {
+	Namespace     string
+	LabelSelector string
+	FieldSelector string
+	Limit         int64
+	Continue      string
+	NameGlob      string
+}
+
 func (wts *WorkflowTemplateServer) ListWorkflowTemplates(ctx context.Context, wftmplReq *WorkflowTemplateListRequest) (*v1alpha1.WorkflowTemplateList, error) {
 	wfClient, _, err := wts.GetWFClient(ctx)
 	if err != nil {
@@ -132,15 +189,59 @@ func (wts *WorkflowTemplateServer) ListWorkflowTemplates(ctx context.Context, wf
 		namespace = wftmplReq.Namespace
 	}
 
-	wfList, err := wfClient.ArgoprojV1alpha1().WorkflowTemplates(namespace).List(v1.ListOptions{})
+	if err := validateListRequest(wftmplReq); err != nil {
+		return nil, err
+	}
+
+	listOpts := v1.ListOptions{
+		LabelSelector: wftmplReq.LabelSelector,
+		FieldSelector: wftmplReq.FieldSelector,
+		Limit:         wftmplReq.Limit,
+		Continue:      wftmplReq.Continue,
+	}
+
+	wfList, err := wfClient.ArgoprojV1alpha1().WorkflowTemplates(namespace).List(listOpts)
 
 	if err != nil {
 		return nil, err
 	}
 
+	if wftmplReq.NameGlob != "" {
+		filtered, err := filterByNameGlob(wfList.Items, wftmplReq.NameGlob)
+		if err != nil {
+			return nil, err
+		}
+		wfList.Items = filtered
+	}
+
 	return wfList, nil
 }
 
+// validateListRequest rejects a WorkflowTemplateListRequest combining NameGlob with Limit/Continue: the
+// glob is applied to a single apiserver page and may not match anything on it while matching items on
+// later pages, which would make the page look empty and its Continue/RemainingItemCount misleading.
+func validateListRequest(wftmplReq *WorkflowTemplateListRequest) error {
+	if wftmplReq.NameGlob != "" && (wftmplReq.Limit > 0 || wftmplReq.Continue != "") {
+		return status.Errorf(codes.InvalidArgument, "NameGlob cannot be combined with Limit/Continue: a glob is applied to a single apiserver page and may not match anything on it while matching items on later pages")
+	}
+	return nil
+}
+
+// filterByNameGlob returns the items whose Name matches glob, using shell glob syntax.
+func filterByNameGlob(items []v1alpha1.WorkflowTemplate, glob string) ([]v1alpha1.WorkflowTemplate, error) {
+	filtered := make([]v1alpha1.WorkflowTemplate, 0, len(items))
+	for _, item := range items {
+		matched, err := filepath.Match(glob, item.Name)
+		if err != nil {
+			return nil, fmt.Errorf("invalid name glob '%s': %v", glob, err)
+		}
+		if matched {
+			filtered = append(filtered, item)
+		}
+	}
+	return filtered, nil
+}
+
 func (wts *WorkflowTemplateServer) DeleteWorkflowTemplate(ctx context.Context, wftmplReq *WorkflowTemplateDeleteRequest) (*WorkflowDeleteResponse, error) {
 	wfClient, _, err := wts.GetWFClient(ctx)
 	if err != nil {
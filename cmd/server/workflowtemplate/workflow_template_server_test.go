@@ -0,0 +1,61 @@
+package workflowtemplate
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/argoproj/argo/pkg/apis/workflow/v1alpha1"
+)
+
+func TestValidateListRequest(t *testing.T) {
+	t.Run("NameGlob alone is allowed", func(t *testing.T) {
+		err := validateListRequest(&WorkflowTemplateListRequest{NameGlob: "*report*"})
+		assert.NoError(t, err)
+	})
+
+	t.Run("Limit/Continue alone is allowed", func(t *testing.T) {
+		err := validateListRequest(&WorkflowTemplateListRequest{Limit: 10, Continue: "abc"})
+		assert.NoError(t, err)
+	})
+
+	t.Run("NameGlob with Limit is rejected", func(t *testing.T) {
+		err := validateListRequest(&WorkflowTemplateListRequest{NameGlob: "*report*", Limit: 10})
+		st, ok := status.FromError(err)
+		assert.True(t, ok)
+		assert.Equal(t, codes.InvalidArgument, st.Code())
+	})
+
+	t.Run("NameGlob with Continue is rejected", func(t *testing.T) {
+		err := validateListRequest(&WorkflowTemplateListRequest{NameGlob: "*report*", Continue: "abc"})
+		st, ok := status.FromError(err)
+		assert.True(t, ok)
+		assert.Equal(t, codes.InvalidArgument, st.Code())
+	})
+}
+
+func TestFilterByNameGlob(t *testing.T) {
+	items := []v1alpha1.WorkflowTemplate{
+		{ObjectMeta: v1.ObjectMeta{Name: "daily-report"}},
+		{ObjectMeta: v1.ObjectMeta{Name: "weekly-report"}},
+		{ObjectMeta: v1.ObjectMeta{Name: "build"}},
+	}
+
+	t.Run("glob matches by pattern, not substring", func(t *testing.T) {
+		matched, err := filterByNameGlob(items, "*report")
+		assert.NoError(t, err)
+		assert.Len(t, matched, 2)
+
+		matched, err = filterByNameGlob(items, "report")
+		assert.NoError(t, err)
+		assert.Empty(t, matched)
+	})
+
+	t.Run("invalid glob syntax errors", func(t *testing.T) {
+		_, err := filterByNameGlob(items, "[")
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,121 @@
+// Package initialize provides idempotent helpers for bootstrapping a cluster with the resources a
+// WorkflowTemplateServer needs before it can serve requests: the namespace, the WorkflowTemplate CRD,
+// and an optional library of seed WorkflowTemplates.
+package initialize
+
+import (
+	"fmt"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apiextensionsv1beta1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1beta1"
+	apiextensionsclient "k8s.io/apiextensions-apiserver/pkg/client/clientset/clientset"
+	apierr "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/kubernetes"
+
+	"github.com/argoproj/argo/pkg/apis/workflow/v1alpha1"
+	"github.com/argoproj/argo/pkg/client/clientset/versioned"
+)
+
+// crdEstablishedPollInterval/Timeout bound how long EnsureWorkflowTemplateCRD waits for the apiserver to
+// mark a freshly registered CRD Established. Seed templates can't be created before that happens, even
+// though the Create call for the CRD itself has already returned. Declared as vars, rather than consts,
+// so tests can shrink them instead of waiting out the real timeout.
+var (
+	crdEstablishedPollInterval = 200 * time.Millisecond
+	crdEstablishedPollTimeout  = 30 * time.Second
+)
+
+// WorkflowTemplateCRDName is the fully qualified name of the WorkflowTemplate CustomResourceDefinition.
+const WorkflowTemplateCRDName = "workflowtemplates.argoproj.io"
+
+// Status is the outcome of applying a single seed WorkflowTemplate during initialization.
+type Status string
+
+const (
+	StatusCreated       Status = "Created"
+	StatusAlreadyExists Status = "AlreadyExists"
+	StatusFailed        Status = "Failed"
+)
+
+// ItemResult records what happened when a seed WorkflowTemplate was applied.
+type ItemResult struct {
+	Name    string
+	Status  Status
+	Message string
+}
+
+// EnsureNamespace creates namespace if it does not already exist, tolerating AlreadyExists.
+func EnsureNamespace(kubeClientset kubernetes.Interface, namespace string) error {
+	_, err := kubeClientset.CoreV1().Namespaces().Create(&corev1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{Name: namespace},
+	})
+	if err != nil && !apierr.IsAlreadyExists(err) {
+		return fmt.Errorf("failed to ensure namespace '%s': %v", namespace, err)
+	}
+	return nil
+}
+
+// EnsureWorkflowTemplateCRD registers the WorkflowTemplate CRD if it is not already registered, and waits
+// for the apiserver to report it Established before returning. Callers must not create custom resources
+// of a just-registered CRD until it is Established: the REST endpoint for it isn't live yet and Create
+// calls fail with a NotFound-shaped error even though the CRD object itself now exists.
+func EnsureWorkflowTemplateCRD(apiextensionsClientset apiextensionsclient.Interface) error {
+	crd := &apiextensionsv1beta1.CustomResourceDefinition{
+		ObjectMeta: metav1.ObjectMeta{Name: WorkflowTemplateCRDName},
+		Spec: apiextensionsv1beta1.CustomResourceDefinitionSpec{
+			Group:   "argoproj.io",
+			Version: "v1alpha1",
+			Scope:   apiextensionsv1beta1.NamespaceScoped,
+			Names: apiextensionsv1beta1.CustomResourceDefinitionNames{
+				Plural: "workflowtemplates",
+				Kind:   "WorkflowTemplate",
+			},
+		},
+	}
+	_, err := apiextensionsClientset.ApiextensionsV1beta1().CustomResourceDefinitions().Create(crd)
+	if err != nil && !apierr.IsAlreadyExists(err) {
+		return fmt.Errorf("failed to ensure WorkflowTemplate CRD: %v", err)
+	}
+
+	if err := waitForCRDEstablished(apiextensionsClientset, WorkflowTemplateCRDName); err != nil {
+		return fmt.Errorf("failed waiting for WorkflowTemplate CRD to become established: %v", err)
+	}
+	return nil
+}
+
+// waitForCRDEstablished polls name until its Established condition is True.
+func waitForCRDEstablished(apiextensionsClientset apiextensionsclient.Interface, name string) error {
+	return wait.PollImmediate(crdEstablishedPollInterval, crdEstablishedPollTimeout, func() (bool, error) {
+		crd, err := apiextensionsClientset.ApiextensionsV1beta1().CustomResourceDefinitions().Get(name, metav1.GetOptions{})
+		if err != nil {
+			return false, err
+		}
+		for _, cond := range crd.Status.Conditions {
+			if cond.Type == apiextensionsv1beta1.Established && cond.Status == apiextensionsv1beta1.ConditionTrue {
+				return true, nil
+			}
+		}
+		return false, nil
+	})
+}
+
+// CreateSeedTemplates idempotently creates each of seeds in namespace, tolerating AlreadyExists errors,
+// and returns one ItemResult per seed in the order supplied.
+func CreateSeedTemplates(wfClientset versioned.Interface, namespace string, seeds []*v1alpha1.WorkflowTemplate) []ItemResult {
+	results := make([]ItemResult, 0, len(seeds))
+	for _, seed := range seeds {
+		_, err := wfClientset.ArgoprojV1alpha1().WorkflowTemplates(namespace).Create(seed)
+		switch {
+		case err == nil:
+			results = append(results, ItemResult{Name: seed.Name, Status: StatusCreated})
+		case apierr.IsAlreadyExists(err):
+			results = append(results, ItemResult{Name: seed.Name, Status: StatusAlreadyExists})
+		default:
+			results = append(results, ItemResult{Name: seed.Name, Status: StatusFailed, Message: err.Error()})
+		}
+	}
+	return results
+}
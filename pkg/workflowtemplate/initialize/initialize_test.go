@@ -0,0 +1,98 @@
+package initialize
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	apiextensionsv1beta1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1beta1"
+	apiextensionsfake "k8s.io/apiextensions-apiserver/pkg/client/clientset/clientset/fake"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kubefake "k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+
+	"github.com/argoproj/argo/pkg/apis/workflow/v1alpha1"
+	wftmplfake "github.com/argoproj/argo/pkg/client/clientset/versioned/fake"
+)
+
+func TestEnsureNamespace(t *testing.T) {
+	t.Run("creates a missing namespace", func(t *testing.T) {
+		kubeClientset := kubefake.NewSimpleClientset()
+		err := EnsureNamespace(kubeClientset, "my-ns")
+		assert.NoError(t, err)
+		_, err = kubeClientset.CoreV1().Namespaces().Get("my-ns", metav1.GetOptions{})
+		assert.NoError(t, err)
+	})
+
+	t.Run("tolerates an existing namespace", func(t *testing.T) {
+		kubeClientset := kubefake.NewSimpleClientset(&corev1.Namespace{
+			ObjectMeta: metav1.ObjectMeta{Name: "my-ns"},
+		})
+		err := EnsureNamespace(kubeClientset, "my-ns")
+		assert.NoError(t, err)
+	})
+}
+
+func TestWaitForCRDEstablished(t *testing.T) {
+	t.Run("returns once Established is True", func(t *testing.T) {
+		crd := &apiextensionsv1beta1.CustomResourceDefinition{
+			ObjectMeta: metav1.ObjectMeta{Name: WorkflowTemplateCRDName},
+			Status: apiextensionsv1beta1.CustomResourceDefinitionStatus{
+				Conditions: []apiextensionsv1beta1.CustomResourceDefinitionCondition{
+					{Type: apiextensionsv1beta1.Established, Status: apiextensionsv1beta1.ConditionTrue},
+				},
+			},
+		}
+		apiextensionsClientset := apiextensionsfake.NewSimpleClientset(crd)
+
+		err := waitForCRDEstablished(apiextensionsClientset, WorkflowTemplateCRDName)
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("times out if it never becomes Established", func(t *testing.T) {
+		crd := &apiextensionsv1beta1.CustomResourceDefinition{
+			ObjectMeta: metav1.ObjectMeta{Name: WorkflowTemplateCRDName},
+		}
+		apiextensionsClientset := apiextensionsfake.NewSimpleClientset(crd)
+
+		savedInterval, savedTimeout := crdEstablishedPollInterval, crdEstablishedPollTimeout
+		crdEstablishedPollInterval = time.Millisecond
+		crdEstablishedPollTimeout = 10 * time.Millisecond
+		defer func() { crdEstablishedPollInterval, crdEstablishedPollTimeout = savedInterval, savedTimeout }()
+
+		err := waitForCRDEstablished(apiextensionsClientset, WorkflowTemplateCRDName)
+
+		assert.Error(t, err)
+	})
+}
+
+func TestCreateSeedTemplates(t *testing.T) {
+	existing := &v1alpha1.WorkflowTemplate{ObjectMeta: metav1.ObjectMeta{Name: "existing", Namespace: "default"}}
+	wfClientset := wftmplfake.NewSimpleClientset(existing)
+	wfClientset.PrependReactor("create", "workflowtemplates", func(action k8stesting.Action) (bool, interface{}, error) {
+		create, ok := action.(k8stesting.CreateAction)
+		if ok {
+			if tmpl, ok := create.GetObject().(*v1alpha1.WorkflowTemplate); ok && tmpl.Name == "broken" {
+				return true, nil, errors.New("apiserver is unavailable")
+			}
+		}
+		return false, nil, nil
+	})
+
+	seeds := []*v1alpha1.WorkflowTemplate{
+		{ObjectMeta: metav1.ObjectMeta{Name: "new"}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "existing"}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "broken"}},
+	}
+
+	results := CreateSeedTemplates(wfClientset, "default", seeds)
+
+	assert.Equal(t, []ItemResult{
+		{Name: "new", Status: StatusCreated},
+		{Name: "existing", Status: StatusAlreadyExists},
+		{Name: "broken", Status: StatusFailed, Message: "apiserver is unavailable"},
+	}, results)
+}